@@ -0,0 +1,49 @@
+// Package apierr define o conjunto fechado de erros que a API pode
+// devolver, cada um com um código estável para o frontend tratar e uma
+// mensagem em português para exibir ao usuário.
+package apierr
+
+import "net/http"
+
+type Code string
+
+const (
+	CodeEmailAlreadyInUse    Code = "email-already-in-use"
+	CodeEmailAlreadyVerified Code = "email-already-verified"
+	CodeInvalidCode          Code = "invalid-code"
+	CodeExpiredCode          Code = "code-expired"
+	CodeTooManyRequests      Code = "too-many-requests"
+	CodeSMTPUnavailable      Code = "smtp-unavailable"
+	CodeInvalidEmailPassword Code = "invalid-email-password"
+	CodeInvalidToken         Code = "invalid-token"
+	CodeValidationFailed     Code = "validation-failed"
+	CodeMessageNotFound      Code = "message-not-found"
+	CodeInternal             Code = "internal-error"
+)
+
+// Error é o envelope único de erro devolvido por toda a API.
+type Error struct {
+	HTTPStatus int    `json:"-"`
+	ErrCode    Code   `json:"code"`
+	Msg        string `json:"message"`
+}
+
+func (e *Error) Error() string { return e.Msg }
+
+func newErr(status int, code Code, msg string) *Error {
+	return &Error{HTTPStatus: status, ErrCode: code, Msg: msg}
+}
+
+var (
+	ErrEmailAlreadyInUse    = newErr(http.StatusConflict, CodeEmailAlreadyInUse, "E-mail já está em uso")
+	ErrEmailAlreadyVerified = newErr(http.StatusConflict, CodeEmailAlreadyVerified, "E-mail já verificado")
+	ErrInvalidCode          = newErr(http.StatusBadRequest, CodeInvalidCode, "Código incorreto")
+	ErrCodeExpired          = newErr(http.StatusBadRequest, CodeExpiredCode, "Código inválido ou expirado")
+	ErrTooManyRequests      = newErr(http.StatusTooManyRequests, CodeTooManyRequests, "Muitas tentativas, tente novamente mais tarde")
+	ErrSMTPUnavailable      = newErr(http.StatusServiceUnavailable, CodeSMTPUnavailable, "Serviço de e-mail indisponível")
+	ErrInvalidEmailPassword = newErr(http.StatusUnauthorized, CodeInvalidEmailPassword, "E-mail ou senha inválidos")
+	ErrInvalidToken         = newErr(http.StatusBadRequest, CodeInvalidToken, "Token inválido ou expirado")
+	ErrValidationFailed     = newErr(http.StatusBadRequest, CodeValidationFailed, "Dados inválidos")
+	ErrMessageNotFound      = newErr(http.StatusNotFound, CodeMessageNotFound, "Mensagem não encontrada")
+	ErrInternal             = newErr(http.StatusInternalServerError, CodeInternal, "Erro interno")
+)
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/joho/godotenv"
+)
+
+type Config struct {
+	Port         string
+	EmailLogOnly bool
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUser     string
+	SMTPPass     string
+	SMTPFrom     string
+	RedisHost    string
+	RedisPort    string
+
+	CodeExpirationMinutes int
+
+	CourierStore          string
+	CourierDBPath         string
+	CourierPollIntervalMS int
+	CourierBatchSize      int
+	CourierMaxSendCount   int
+
+	SMSGatewayURL             string
+	SMSGatewayRequestTemplate string
+	TelegramBotToken          string
+
+	AppSecret            string
+	VerificationMode     string
+	PublicBaseURL        string
+	AllowedRedirectHosts []string
+
+	RateLimitPerEmail          int
+	RateLimitPerEmailWindowMin int
+	RateLimitPerIP             int
+	RateLimitPerIPWindowMin    int
+
+	WebhookURLs   []string
+	WebhookSecret string
+
+	AdminToken string
+}
+
+var AppConfig *Config
+
+func LoadConfig() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Println("Aviso: .env não encontrado, usando valores padrão")
+	}
+
+	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "25"))
+	codeExp, _ := strconv.Atoi(getEnv("CODE_EXPIRATION_MINUTES", "15"))
+	courierPollMS, _ := strconv.Atoi(getEnv("COURIER_POLL_INTERVAL_MS", "2000"))
+	courierBatch, _ := strconv.Atoi(getEnv("COURIER_BATCH_SIZE", "20"))
+	courierMaxSend, _ := strconv.Atoi(getEnv("COURIER_MAX_SEND_COUNT", "5"))
+
+	rateLimitPerEmail, _ := strconv.Atoi(getEnv("RATE_LIMIT_PER_EMAIL", "3"))
+	rateLimitPerEmailWindow, _ := strconv.Atoi(getEnv("RATE_LIMIT_PER_EMAIL_WINDOW_MINUTES", "15"))
+	rateLimitPerIP, _ := strconv.Atoi(getEnv("RATE_LIMIT_PER_IP", "30"))
+	rateLimitPerIPWindow, _ := strconv.Atoi(getEnv("RATE_LIMIT_PER_IP_WINDOW_MINUTES", "60"))
+
+	AppConfig = &Config{
+		Port:         getEnv("PORT", "8080"),
+		EmailLogOnly: getEnv("EMAIL_LOG_ONLY", "0") == "1",
+		SMTPHost:     getEnv("SMTP_HOST", "127.0.0.1"),
+		SMTPPort:     smtpPort,
+		SMTPUser:     getEnv("SMTP_USER", ""),
+		SMTPPass:     getEnv("SMTP_PASS", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", "no-reply@seusite.com"),
+		RedisHost:    getEnv("REDIS_HOST", "localhost"),
+		RedisPort:    getEnv("REDIS_PORT", "6379"),
+
+		CodeExpirationMinutes: codeExp,
+
+		CourierStore:          getEnv("COURIER_STORE", "sqlite"),
+		CourierDBPath:         getEnv("COURIER_DB_PATH", "./courier.db"),
+		CourierPollIntervalMS: courierPollMS,
+		CourierBatchSize:      courierBatch,
+		CourierMaxSendCount:   courierMaxSend,
+
+		SMSGatewayURL:             getEnv("SMS_GATEWAY_URL", ""),
+		SMSGatewayRequestTemplate: getEnv("SMS_GATEWAY_REQUEST_TEMPLATE", `{"to":"{{.To | jsonEscape}}","message":"{{.Body | jsonEscape}}"}`),
+		TelegramBotToken:          getEnv("TELEGRAM_BOT_TOKEN", ""),
+
+		AppSecret:            getEnv("APP_SECRET", ""),
+		VerificationMode:     getEnv("VERIFICATION_MODE", "code"),
+		PublicBaseURL:        getEnv("PUBLIC_BASE_URL", "http://localhost:8080"),
+		AllowedRedirectHosts: getEnvList("ALLOWED_REDIRECT_HOSTS"),
+
+		RateLimitPerEmail:          rateLimitPerEmail,
+		RateLimitPerEmailWindowMin: rateLimitPerEmailWindow,
+		RateLimitPerIP:             rateLimitPerIP,
+		RateLimitPerIPWindowMin:    rateLimitPerIPWindow,
+
+		WebhookURLs:   getEnvList("WEBHOOK_URLS"),
+		WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
+
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+	}
+}
+
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+func getEnvList(key string) []string {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return nil
+	}
+
+	var out []string
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
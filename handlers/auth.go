@@ -0,0 +1,505 @@
+package handlers
+
+import (
+	"email-api/apierr"
+	"email-api/config"
+	"email-api/services"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthHandler struct {
+	emailService       *services.EmailService
+	storage            *services.CodeStorage
+	courier            *services.Courier
+	courierStore       services.CourierStore
+	rateLimiter        *services.RateLimiter
+	webhooks           *services.WebhookDispatcher
+	webhookDeadLetters *services.WebhookDeadLetterStore
+
+	emailChannel    *services.EmailChannel
+	smsChannel      *services.SMSChannel
+	telegramChannel *services.TelegramChannel
+}
+
+func NewAuthHandler(
+	courier *services.Courier,
+	courierStore services.CourierStore,
+	smsChannel *services.SMSChannel,
+	telegramChannel *services.TelegramChannel,
+	rateLimiter *services.RateLimiter,
+	webhooks *services.WebhookDispatcher,
+	webhookDeadLetters *services.WebhookDeadLetterStore,
+) *AuthHandler {
+	emailService := services.NewEmailService()
+
+	return &AuthHandler{
+		emailService:       emailService,
+		storage:            services.NewCodeStorage(),
+		courier:            courier,
+		courierStore:       courierStore,
+		rateLimiter:        rateLimiter,
+		webhooks:           webhooks,
+		webhookDeadLetters: webhookDeadLetters,
+		emailChannel:       services.NewEmailChannel(emailService, courier),
+		smsChannel:         smsChannel,
+		telegramChannel:    telegramChannel,
+	}
+}
+
+// emit dispara um evento de webhook; webhooks é opcional (pode ser nil
+// quando WEBHOOK_URLS não está configurado).
+func (h *AuthHandler) emit(event services.WebhookEvent) {
+	if h.webhooks != nil {
+		h.webhooks.Emit(event)
+	}
+}
+
+// respondErr escreve o envelope de erro e encerra a requisição; é o único
+// jeito que os handlers têm de responder um erro a partir de agora.
+func respondErr(c *gin.Context, err *apierr.Error) {
+	c.JSON(err.HTTPStatus, gin.H{
+		"code":    err.ErrCode,
+		"message": err.Msg,
+	})
+}
+
+func (h *AuthHandler) Courier() *services.Courier {
+	return h.courier
+}
+
+func (h *AuthHandler) codeExpirationMinutes() int {
+	return config.AppConfig.CodeExpirationMinutes
+}
+
+// buildVerifyURL e buildResetURL só emitem um link quando VERIFICATION_MODE
+// é "link" ou "both"; caso contrário devolvem "" e os templates de e-mail
+// enviam somente o código, como antes.
+func (h *AuthHandler) buildVerifyURL(email string) string {
+	cfg := config.AppConfig
+	if cfg.VerificationMode != "link" && cfg.VerificationMode != "both" {
+		return ""
+	}
+
+	ttl := time.Duration(cfg.CodeExpirationMinutes) * time.Minute
+	token, nonce, err := services.NewMagicToken(email, services.PurposeVerify, ttl)
+	if err != nil {
+		return ""
+	}
+
+	h.storage.SaveVerificationNonce(email, nonce)
+	return fmt.Sprintf("%s/api/auth/verify?token=%s", cfg.PublicBaseURL, url.QueryEscape(token))
+}
+
+func (h *AuthHandler) buildResetURL(email string) string {
+	cfg := config.AppConfig
+	if cfg.VerificationMode != "link" && cfg.VerificationMode != "both" {
+		return ""
+	}
+
+	ttl := time.Duration(cfg.CodeExpirationMinutes) * time.Minute
+	token, nonce, err := services.NewMagicToken(email, services.PurposeReset, ttl)
+	if err != nil {
+		return ""
+	}
+
+	h.storage.SaveResetNonce(email, nonce)
+	return fmt.Sprintf("%s/api/auth/reset?token=%s", cfg.PublicBaseURL, url.QueryEscape(token))
+}
+
+// isAllowedRedirect compara o host da URL de redirecionamento contra
+// ALLOWED_REDIRECT_HOSTS. A comparação é feita com net/url e pelo host
+// exato (não um prefixo de string) para evitar bypasses como
+// "https://app.seusite.com.evil.com", que passaria em um HasPrefix simples.
+func isAllowedRedirect(raw string) bool {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	for _, allowed := range config.AppConfig.AllowedRedirectHosts {
+		allowedURL, err := url.Parse(allowed)
+		if err != nil || allowedURL.Host == "" {
+			continue
+		}
+
+		if parsed.Scheme == allowedURL.Scheme && parsed.Host == allowedURL.Host {
+			return true
+		}
+	}
+
+	return false
+}
+
+// channelFor resolve qual services.Channel e identificador usar para a
+// requisição, com "email" como padrão para manter compatibilidade. Para
+// "telegram" o telegram_id nunca vem do corpo da requisição: ele só existe
+// depois que a conta passou pelo fluxo de /link/telegram + confirmação do
+// bot (ver ConfirmTelegramLink), então é resolvido aqui a partir do vínculo
+// persistido por e-mail. Sem vínculo, o identificador volta vazio e nenhum
+// código é enviado — um telegram_id arbitrário no corpo não tem efeito.
+func (h *AuthHandler) channelFor(channel, email, phone string) (services.Channel, string) {
+	switch channel {
+	case "sms":
+		return h.smsChannel, phone
+	case "telegram":
+		telegramID, _ := h.storage.GetTelegramBinding(email)
+		return h.telegramChannel, telegramID
+	default:
+		return h.emailChannel, email
+	}
+}
+
+// resolveLang decide o locale do e-mail: o campo Lang do corpo tem
+// prioridade; na ausência, cai para a tag primária do cabeçalho
+// Accept-Language (ex.: "en-US,en;q=0.9" -> "en"). Vazio deixa o
+// TemplateRegistry cair no template padrão (pt-BR). Só EmailChannel
+// localiza — sms/telegram mandam texto fixo.
+func resolveLang(c *gin.Context, reqLang string) string {
+	if reqLang != "" {
+		return reqLang
+	}
+
+	header := c.GetHeader("Accept-Language")
+	if header == "" {
+		return ""
+	}
+
+	tag := strings.TrimSpace(strings.Split(header, ",")[0])
+	tag = strings.Split(tag, ";")[0]
+	tag = strings.Split(tag, "-")[0]
+	return strings.ToLower(tag)
+}
+
+type RegisterRequest struct {
+	Email   string `json:"email" binding:"required_without=Phone,omitempty,email"`
+	Channel string `json:"channel" binding:"omitempty,oneof=email sms telegram"`
+	Phone   string `json:"phone"`
+	Lang    string `json:"lang"`
+}
+
+type VerifyCodeRequest struct {
+	Email   string `json:"email" binding:"required_without=Phone,omitempty,email"`
+	Channel string `json:"channel" binding:"omitempty,oneof=email sms telegram"`
+	Phone   string `json:"phone"`
+	Code    string `json:"code" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Email       string `json:"email" binding:"required_without=Phone,omitempty,email"`
+	Channel     string `json:"channel" binding:"omitempty,oneof=email sms telegram"`
+	Phone       string `json:"phone"`
+	Code        string `json:"code" binding:"required"`
+	NewPassword string `json:"newPassword" binding:"required,min=6"`
+}
+
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req RegisterRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	channel, identifier := h.channelFor(req.Channel, req.Email, req.Phone)
+
+	// identifier vazio significa que o canal pedido não tem destino: canal
+	// "telegram" sem vínculo confirmado (ver channelFor), ou o campo
+	// email/phone do canal ausente; não há para onde enviar, mas a resposta
+	// continua a mesma por anti-enumeração.
+	if identifier == "" {
+		log.Printf("[auth] register: canal %s sem identificador resolvido para %s, nenhum código enviado\n", channel.Name(), req.Email)
+	} else {
+		// O rate limit por e-mail falha aberto, exatamente como RateLimitByIP:
+		// se o Redis estiver fora do ar, preferimos deixar a requisição passar
+		// a derrubar o cadastro inteiro por causa de uma dependência externa.
+		window := time.Duration(config.AppConfig.RateLimitPerEmailWindowMin) * time.Minute
+		allowed, retryAfter, err := h.rateLimiter.Allow(c, services.EmailRateLimitKey(identifier), config.AppConfig.RateLimitPerEmail, window)
+		if err != nil {
+			log.Printf("[auth] register: falha ao consultar rate limit, liberando requisição: %v\n", err)
+		} else if !allowed {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(retryAfter.Seconds())))
+			respondErr(c, apierr.ErrTooManyRequests)
+			return
+		}
+
+		// Anti-enumeração: a resposta é sempre a mesma, exista ou não o
+		// e-mail/telefone — só o log interno sabe a diferença.
+		code := h.storage.GenerateCode()
+		h.storage.SaveVerificationCode(channel.Name(), identifier, code)
+		h.emit(services.WebhookEvent{Type: services.EventCodeIssued, Email: identifier, Channel: channel.Name()})
+
+		var sendErr error
+		if emailChannel, ok := channel.(*services.EmailChannel); ok {
+			sendErr = emailChannel.SendVerificationCode(identifier, code, h.buildVerifyURL(identifier), resolveLang(c, req.Lang))
+		} else {
+			sendErr = channel.SendCode(identifier, code, h.codeExpirationMinutes())
+		}
+
+		if sendErr != nil {
+			log.Printf("[auth] register: falha ao enviar para %s via %s: %v\n", identifier, channel.Name(), sendErr)
+		}
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Se o identificador existir, um código foi enviado",
+	})
+}
+
+func (h *AuthHandler) VerifyCode(c *gin.Context) {
+	var req VerifyCodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	channel, identifier := h.channelFor(req.Channel, req.Email, req.Phone)
+	stored := h.storage.GetVerificationCode(channel.Name(), identifier)
+
+	if stored == nil {
+		h.emit(services.WebhookEvent{Type: services.EventCodeExpired, Email: identifier, Channel: channel.Name()})
+		respondErr(c, apierr.ErrCodeExpired)
+		return
+	}
+
+	if stored.Code != req.Code {
+		respondErr(c, apierr.ErrInvalidCode)
+		return
+	}
+
+	h.storage.DeleteVerificationCode(channel.Name(), identifier)
+	h.emit(services.WebhookEvent{Type: services.EventCodeVerified, Email: identifier, Channel: channel.Name()})
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Código verificado com sucesso",
+	})
+}
+
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req RegisterRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	channel, identifier := h.channelFor(req.Channel, req.Email, req.Phone)
+
+	// Mesmo formato de Register: identifier vazio quer dizer canal sem
+	// destino ("telegram" sem vínculo confirmado, ou email/phone ausente), e
+	// o rate limit por e-mail falha aberto. A
+	// resposta de sucesso é incondicional — conta inexistente, e-mail não
+	// verificado ou canal sem vínculo só aparecem no log, nunca no corpo
+	// da resposta.
+	if identifier == "" {
+		log.Printf("[auth] forgot-password: canal %s sem identificador resolvido para %s, nenhum código enviado\n", channel.Name(), req.Email)
+	} else {
+		window := time.Duration(config.AppConfig.RateLimitPerEmailWindowMin) * time.Minute
+		allowed, retryAfter, err := h.rateLimiter.Allow(c, services.EmailRateLimitKey(identifier), config.AppConfig.RateLimitPerEmail, window)
+		if err != nil {
+			log.Printf("[auth] forgot-password: falha ao consultar rate limit, liberando requisição: %v\n", err)
+		} else if !allowed {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(retryAfter.Seconds())))
+			respondErr(c, apierr.ErrTooManyRequests)
+			return
+		}
+
+		code := h.storage.GenerateCode()
+		h.storage.SaveResetCode(channel.Name(), identifier, code)
+
+		var sendErr error
+		if emailChannel, ok := channel.(*services.EmailChannel); ok {
+			sendErr = emailChannel.SendPasswordResetCode(identifier, code, h.buildResetURL(identifier), resolveLang(c, req.Lang))
+		} else {
+			sendErr = channel.SendCode(identifier, code, h.codeExpirationMinutes())
+		}
+
+		if sendErr != nil {
+			log.Printf("[auth] forgot-password: falha ao enviar para %s via %s: %v\n", identifier, channel.Name(), sendErr)
+		}
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Se o identificador existir, um código de recuperação foi enviado",
+	})
+}
+
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	channel, identifier := h.channelFor(req.Channel, req.Email, req.Phone)
+	stored := h.storage.GetResetCode(channel.Name(), identifier)
+
+	if stored == nil {
+		respondErr(c, apierr.ErrCodeExpired)
+		return
+	}
+
+	if stored.Code != req.Code {
+		respondErr(c, apierr.ErrInvalidCode)
+		return
+	}
+
+	h.storage.DeleteResetCode(channel.Name(), identifier)
+
+	// Aqui você atualizaria a senha no banco
+	// userService.UpdatePassword(identifier, req.NewPassword)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Senha atualizada com sucesso",
+	})
+}
+
+type LinkTelegramRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// LinkTelegram emite um nonce de curta duração que o usuário encaminha
+// para o bot; o bot (fora deste serviço) troca esse nonce pelo
+// telegram_id de quem mandou a mensagem e chama de volta para vincular.
+func (h *AuthHandler) LinkTelegram(c *gin.Context) {
+	var req LinkTelegramRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	nonce := h.storage.GenerateCode()
+	h.storage.SaveLinkNonce("telegram", req.Email, nonce)
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"nonce":   nonce,
+		"message": "Envie este código para o bot no Telegram para vincular sua conta",
+	})
+}
+
+type ConfirmTelegramLinkRequest struct {
+	Email      string `json:"email" binding:"required,email"`
+	Nonce      string `json:"nonce" binding:"required"`
+	TelegramID string `json:"telegram_id" binding:"required"`
+}
+
+// ConfirmTelegramLink é chamado pelo bot do Telegram (fora deste serviço)
+// depois que o usuário encaminha o nonce emitido por LinkTelegram: troca o
+// nonce pelo telegram_id de quem mandou a mensagem e persiste o vínculo
+// conta -> chat que channelFor passa a exigir para liberar o canal
+// "telegram" em Register/VerifyCode/ForgotPassword/ResetPassword.
+func (h *AuthHandler) ConfirmTelegramLink(c *gin.Context) {
+	var req ConfirmTelegramLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	if !h.storage.ConfirmTelegramLink(req.Email, req.Nonce, req.TelegramID) {
+		respondErr(c, apierr.ErrInvalidToken)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Telegram vinculado com sucesso",
+	})
+}
+
+type VerifyLinkRequest struct {
+	Token       string `form:"token" binding:"required"`
+	RedirectURL string `form:"redirect_url"`
+}
+
+// VerifyLink é o handler de GET /api/auth/verify: valida o token, garante
+// uso único via o nonce e, se houver um redirect_url permitido, manda o
+// usuário de volta para o frontend em vez de devolver JSON cru.
+func (h *AuthHandler) VerifyLink(c *gin.Context) {
+	var req VerifyLinkRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	payload, err := services.ParseMagicToken(req.Token)
+	if err != nil || payload.Purpose != services.PurposeVerify {
+		respondErr(c, apierr.ErrInvalidToken)
+		return
+	}
+
+	if !h.storage.ConsumeVerificationNonce(payload.Email, payload.Nonce) {
+		respondErr(c, apierr.ErrInvalidToken)
+		return
+	}
+
+	if req.RedirectURL != "" && isAllowedRedirect(req.RedirectURL) {
+		c.Redirect(http.StatusFound, req.RedirectURL)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "E-mail verificado com sucesso"})
+}
+
+// ResetLink é o handler de GET /api/auth/reset: mesmo formato de
+// VerifyLink, mas consome o nonce de reset e redireciona para a página de
+// redefinição de senha do frontend em vez de marcar o e-mail como verificado.
+func (h *AuthHandler) ResetLink(c *gin.Context) {
+	var req VerifyLinkRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	payload, err := services.ParseMagicToken(req.Token)
+	if err != nil || payload.Purpose != services.PurposeReset {
+		respondErr(c, apierr.ErrInvalidToken)
+		return
+	}
+
+	if !h.storage.ConsumeResetNonce(payload.Email, payload.Nonce) {
+		respondErr(c, apierr.ErrInvalidToken)
+		return
+	}
+
+	if req.RedirectURL != "" && isAllowedRedirect(req.RedirectURL) {
+		c.Redirect(http.StatusFound, req.RedirectURL)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "email": payload.Email, "message": "Token válido, prossiga com a redefinição de senha"})
+}
+
+func (h *AuthHandler) Health(c *gin.Context) {
+	smtpConnected := h.emailService.VerifyConnection()
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+		"smtp":   smtpConnected,
+	})
+}
+
+func (h *AuthHandler) CourierStatus(c *gin.Context) {
+	depth, lastErr, err := h.courier.Status()
+	if err != nil {
+		respondErr(c, apierr.ErrInternal)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"queueDepth": depth,
+		"lastError":  lastErr,
+	})
+}
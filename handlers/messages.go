@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"email-api/apierr"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetMessage devolve uma linha persistida do courier por ID, para
+// depurar um envio específico sem precisar vasculhar logs.
+func (h *AuthHandler) GetMessage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	msg, err := h.courierStore.Get(id)
+	if err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+	if msg == nil {
+		respondErr(c, apierr.ErrMessageNotFound)
+		return
+	}
+
+	c.JSON(http.StatusOK, msg)
+}
+
+// ListMessages filtra as mensagens persistidas por e-mail e/ou status.
+func (h *AuthHandler) ListMessages(c *gin.Context) {
+	messages, err := h.courierStore.List(c.Query("email"), c.Query("status"))
+	if err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// ListWebhookDeadLetters expõe os eventos de webhook que esgotaram as
+// retentativas — persistidos em services.WebhookDeadLetterStore, então
+// continuam consultáveis mesmo depois de um restart do processo.
+func (h *AuthHandler) ListWebhookDeadLetters(c *gin.Context) {
+	deadLetters, err := h.webhookDeadLetters.List()
+	if err != nil {
+		respondErr(c, apierr.ErrValidationFailed)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deadLetters": deadLetters})
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"email-api/apierr"
+	"email-api/config"
+	"email-api/services"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitByIP aplica o limite "por IP" a toda a rota; o limite "por
+// e-mail" é checado dentro de cada handler, já que só ali se conhece o
+// e-mail do corpo da requisição. Em caso de erro do Redis o limite falha
+// aberto: preferimos deixar passar a derrubar o serviço por causa de uma
+// dependência fora do ar.
+func RateLimitByIP(limiter *services.RateLimiter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		cfg := config.AppConfig
+		window := time.Duration(cfg.RateLimitPerIPWindowMin) * time.Minute
+
+		allowed, retryAfter, err := limiter.Allow(c, services.IPRateLimitKey(c.ClientIP()), cfg.RateLimitPerIP, window)
+		if err != nil {
+			log.Printf("[rate-limit] falha ao consultar Redis, liberando requisição: %v\n", err)
+			c.Next()
+			return
+		}
+
+		if !allowed {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(retryAfter.Seconds())))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"code":    apierr.CodeTooManyRequests,
+				"message": apierr.ErrTooManyRequests.Msg,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireAdminToken protege as rotas administrativas com um bearer token
+// simples, comparado em tempo constante.
+func RequireAdminToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(config.AppConfig.AdminToken)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    apierr.CodeInvalidToken,
+				"message": "Token de administrador inválido",
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,10 @@
+package templates
+
+import "net/mail"
+
+// ParseFromAddress resolve SMTP_FROM ("Seu Site <no-reply@seusite.com>")
+// em mail.Address uma única vez, no startup, em vez de repassar a string
+// crua em todo envio.
+func ParseFromAddress(raw string) (*mail.Address, error) {
+	return mail.ParseAddress(raw)
+}
@@ -0,0 +1,140 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+//go:embed *.subject.tmpl *.txt.tmpl *.html.tmpl
+var templatesFS embed.FS
+
+// Rendered é o resultado de renderizar um template para um e-mail.
+type Rendered struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// TemplateRegistry carrega todos os templates embutidos uma única vez e
+// os mantém em memória, indexados por nome + locale.
+type TemplateRegistry struct {
+	subjects map[string]*texttemplate.Template
+	texts    map[string]*texttemplate.Template
+	htmls    map[string]*htmltemplate.Template
+}
+
+// NewTemplateRegistry varre o embed.FS e compila cada arquivo encontrado.
+func NewTemplateRegistry() (*TemplateRegistry, error) {
+	r := &TemplateRegistry{
+		subjects: make(map[string]*texttemplate.Template),
+		texts:    make(map[string]*texttemplate.Template),
+		htmls:    make(map[string]*htmltemplate.Template),
+	}
+
+	entries, err := templatesFS.ReadDir(".")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		content, err := templatesFS.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		key, kind := parseTemplateName(name)
+
+		switch kind {
+		case "subject":
+			tmpl, err := texttemplate.New(name).Parse(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %w", name, err)
+			}
+			r.subjects[key] = tmpl
+		case "txt":
+			tmpl, err := texttemplate.New(name).Parse(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %w", name, err)
+			}
+			r.texts[key] = tmpl
+		case "html":
+			tmpl, err := htmltemplate.New(name).Parse(string(content))
+			if err != nil {
+				return nil, fmt.Errorf("template %s: %w", name, err)
+			}
+			r.htmls[key] = tmpl
+		}
+	}
+
+	return r, nil
+}
+
+// Render produz o assunto + corpo texto/HTML de `name` para o `lang`
+// pedido, caindo para o template sem sufixo de locale quando ele não
+// existir (ex.: "mail-verify.en" ausente cai em "mail-verify"). O locale
+// só é usado se assunto, texto e HTML existirem todos para ele; caso
+// contrário os três caem juntos para o padrão, então um e-mail nunca
+// mistura, por exemplo, HTML localizado com assunto no idioma padrão.
+func (r *TemplateRegistry) Render(name, lang string, data map[string]any) (*Rendered, error) {
+	subjectKey := localizedKey(r.subjects, name, lang)
+	textKey := localizedKey(r.texts, name, lang)
+	htmlKey := localizedKey(r.htmls, name, lang)
+	if subjectKey != textKey || textKey != htmlKey {
+		subjectKey, textKey, htmlKey = name, name, name
+	}
+
+	subjectTmpl, ok := r.subjects[subjectKey]
+	if !ok {
+		return nil, fmt.Errorf("template registry: assunto não encontrado para %q", subjectKey)
+	}
+	textTmpl, ok := r.texts[textKey]
+	if !ok {
+		return nil, fmt.Errorf("template registry: texto não encontrado para %q", textKey)
+	}
+	htmlTmpl, ok := r.htmls[htmlKey]
+	if !ok {
+		return nil, fmt.Errorf("template registry: html não encontrado para %q", htmlKey)
+	}
+
+	var subjectBuf, textBuf, htmlBuf bytes.Buffer
+	if err := subjectTmpl.Execute(&subjectBuf, data); err != nil {
+		return nil, err
+	}
+	if err := textTmpl.Execute(&textBuf, data); err != nil {
+		return nil, err
+	}
+	if err := htmlTmpl.Execute(&htmlBuf, data); err != nil {
+		return nil, err
+	}
+
+	return &Rendered{
+		Subject: strings.TrimSpace(subjectBuf.String()),
+		Text:    textBuf.String(),
+		HTML:    htmlBuf.String(),
+	}, nil
+}
+
+// localizedKey devolve "name.lang" se esse locale existir em m, caindo
+// para "name" (o template padrão) caso contrário.
+func localizedKey[T any](m map[string]T, name, lang string) string {
+	if lang != "" {
+		if _, ok := m[name+"."+lang]; ok {
+			return name + "." + lang
+		}
+	}
+	return name
+}
+
+// parseTemplateName separa "mail-verify.pt-BR.html.tmpl" em
+// ("mail-verify.pt-BR", "html") e "mail-verify.subject.tmpl" em
+// ("mail-verify", "subject").
+func parseTemplateName(filename string) (key, kind string) {
+	name := strings.TrimSuffix(filename, ".tmpl")
+	idx := strings.LastIndex(name, ".")
+	return name[:idx], name[idx+1:]
+}
@@ -0,0 +1,60 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderVerification(t *testing.T) {
+	r, err := NewTemplateRegistry()
+	if err != nil {
+		t.Fatalf("erro ao carregar templates: %v", err)
+	}
+
+	rendered, err := r.Render("mail-verify", "", map[string]any{
+		"Code":              "123456",
+		"ExpirationMinutes": 15,
+	})
+	if err != nil {
+		t.Fatalf("erro ao renderizar: %v", err)
+	}
+
+	if rendered.Subject == "" {
+		t.Fatal("esperava assunto não vazio")
+	}
+	if !strings.Contains(rendered.Text, "123456") {
+		t.Fatalf("esperava código no corpo texto, recebeu: %s", rendered.Text)
+	}
+	if !strings.Contains(rendered.HTML, "123456") {
+		t.Fatalf("esperava código no corpo html, recebeu: %s", rendered.HTML)
+	}
+}
+
+func TestRenderLocaleFallback(t *testing.T) {
+	r, err := NewTemplateRegistry()
+	if err != nil {
+		t.Fatalf("erro ao carregar templates: %v", err)
+	}
+
+	en, err := r.Render("mail-verify", "en", map[string]any{"Code": "123456", "ExpirationMinutes": 15})
+	if err != nil {
+		t.Fatalf("erro ao renderizar em: %v", err)
+	}
+	if !strings.Contains(en.Subject, "verification code") {
+		t.Fatalf("esperava assunto em inglês, recebeu: %s", en.Subject)
+	}
+
+	// "fr" não tem templates próprios; os três tipos devem cair para o
+	// conjunto padrão (pt-BR), sem misturar idiomas entre si.
+	base, err := r.Render("mail-verify", "", map[string]any{"Code": "123456", "ExpirationMinutes": 15})
+	if err != nil {
+		t.Fatalf("erro ao renderizar base: %v", err)
+	}
+	fr, err := r.Render("mail-verify", "fr", map[string]any{"Code": "123456", "ExpirationMinutes": 15})
+	if err != nil {
+		t.Fatalf("erro ao renderizar fr: %v", err)
+	}
+	if fr.Subject != base.Subject || fr.Text != base.Text || fr.HTML != base.HTML {
+		t.Fatal("esperava que locale sem templates próprios caísse inteiramente para o padrão")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"email-api/config"
+	"email-api/handlers"
+	"email-api/services"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+)
+
+func main() {
+	// Carregar configurações
+	config.LoadConfig()
+	cfg := config.AppConfig
+
+	// Configurar Gin
+	router := gin.Default()
+
+	// CORS
+	router.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+
+		c.Next()
+	})
+
+	// Courier (fila persistente de envio)
+	courierStore, err := services.NewCourierStore()
+	if err != nil {
+		log.Fatalf("❌ Erro ao abrir armazenamento do courier: %v\n", err)
+	}
+
+	smsChannel, err := services.NewSMSChannel(cfg.SMSGatewayURL, cfg.SMSGatewayRequestTemplate)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar canal de SMS: %v\n", err)
+	}
+	telegramChannel := services.NewTelegramChannel(cfg.TelegramBotToken)
+
+	redisClient := redis.NewClient(&redis.Options{Addr: cfg.RedisHost + ":" + cfg.RedisPort})
+	rateLimiter := services.NewRateLimiter(redisClient)
+
+	// Webhooks (notificação de eventos do ciclo de vida do courier/código)
+	webhookDeadLetters, err := services.NewWebhookDeadLetterStore(cfg.CourierDBPath)
+	if err != nil {
+		log.Fatalf("❌ Erro ao abrir armazenamento de dead-letter de webhooks: %v\n", err)
+	}
+	webhookDispatcher := services.NewWebhookDispatcher(webhookDeadLetters)
+	webhookDispatcher.Start()
+
+	courier := services.NewCourier(courierStore, services.NewEmailService(), webhookDispatcher)
+	authHandler := handlers.NewAuthHandler(courier, courierStore, smsChannel, telegramChannel, rateLimiter, webhookDispatcher, webhookDeadLetters)
+	authHandler.Courier().Start()
+
+	// Rotas
+	api := router.Group("/api/auth")
+	api.Use(handlers.RateLimitByIP(rateLimiter))
+	{
+		api.POST("/register", authHandler.Register)
+		api.POST("/verify-code", authHandler.VerifyCode)
+		api.POST("/forgot-password", authHandler.ForgotPassword)
+		api.POST("/reset-password", authHandler.ResetPassword)
+		api.POST("/link/telegram", authHandler.LinkTelegram)
+		api.POST("/link/telegram/confirm", authHandler.ConfirmTelegramLink)
+		api.GET("/verify", authHandler.VerifyLink)
+		api.GET("/reset", authHandler.ResetLink)
+		api.GET("/health", authHandler.Health)
+		api.GET("/courier/status", authHandler.CourierStatus)
+	}
+
+	admin := api.Group("")
+	admin.Use(handlers.RequireAdminToken())
+	{
+		admin.GET("/messages/:id", authHandler.GetMessage)
+		admin.GET("/messages", authHandler.ListMessages)
+		admin.GET("/webhooks/dead-letters", authHandler.ListWebhookDeadLetters)
+	}
+
+	router.GET("/", func(c *gin.Context) {
+		c.JSON(200, gin.H{
+			"status":  "ok",
+			"message": "Email API Go",
+			"version": "1.0.0",
+		})
+	})
+
+	// Iniciar servidor com graceful shutdown, drenando a fila do courier
+	srv := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+
+	go func() {
+		log.Printf("🚀 Servidor iniciando na porta %s\n", cfg.Port)
+		log.Printf("📧 SMTP: %s:%d\n", cfg.SMTPHost, cfg.SMTPPort)
+
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("❌ Erro ao iniciar servidor: %v\n", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := authHandler.Courier().Stop(shutdownCtx); err != nil {
+		log.Printf("⚠️ Erro ao drenar courier: %v\n", err)
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("⚠️ Erro ao encerrar servidor: %v\n", err)
+	}
+}
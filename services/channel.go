@@ -0,0 +1,9 @@
+package services
+
+// Channel é a abstração comum de entrega: e-mail, SMS e Telegram
+// implementam a mesma interface, e CodeStorage/Courier não precisam
+// saber qual delas está em uso.
+type Channel interface {
+	Name() string
+	SendCode(identifier, code string, expirationMinutes int) error
+}
@@ -0,0 +1,40 @@
+package services
+
+// EmailChannel adapta o EmailService (e o Courier) para a interface
+// Channel, preservando o comportamento já existente.
+type EmailChannel struct {
+	emailService *EmailService
+	courier      *Courier
+}
+
+func NewEmailChannel(emailService *EmailService, courier *Courier) *EmailChannel {
+	return &EmailChannel{emailService: emailService, courier: courier}
+}
+
+func (c *EmailChannel) Name() string { return "email" }
+
+func (c *EmailChannel) SendCode(identifier, code string, expirationMinutes int) error {
+	return c.SendVerificationCode(identifier, code, "", "")
+}
+
+// SendVerificationCode e SendPasswordResetCode existem além de SendCode
+// (exigido pela interface Channel) porque só o e-mail suporta o link
+// mágico opcional e a localização por idioma: verifyURL/resetURL ficam
+// vazios quando VERIFICATION_MODE="code", e lang vazio cai no template
+// padrão — em ambos os casos os templates simplesmente não renderizam a
+// variação.
+func (c *EmailChannel) SendVerificationCode(identifier, code, verifyURL, lang string) error {
+	msg, err := c.emailService.BuildVerificationMessage(identifier, code, verifyURL, lang)
+	if err != nil {
+		return err
+	}
+	return c.courier.Enqueue(msg)
+}
+
+func (c *EmailChannel) SendPasswordResetCode(identifier, code, resetURL, lang string) error {
+	msg, err := c.emailService.BuildPasswordResetMessage(identifier, code, resetURL, lang)
+	if err != nil {
+		return err
+	}
+	return c.courier.Enqueue(msg)
+}
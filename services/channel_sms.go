@@ -0,0 +1,75 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+)
+
+// SMSChannel dispara mensagens via um gateway HTTP genérico. O corpo da
+// requisição é um template JSON configurável (SMS_GATEWAY_REQUEST_TEMPLATE),
+// interpolado com {{.To | jsonEscape}} e {{.Body | jsonEscape}} — assim dá
+// pra plugar qualquer operadora sem depender de um SDK específico. O filtro
+// jsonEscape é obrigatório: To vem do campo `phone` da requisição sem
+// validação de formato, então uma interpolação crua quebraria ou injetaria
+// campos no JSON enviado ao gateway caso o telefone contenha `"` ou `\`.
+type SMSChannel struct {
+	gatewayURL string
+	bodyTmpl   *template.Template
+	httpClient *http.Client
+}
+
+// jsonEscape devolve s pronto para colar dentro de uma string JSON já
+// delimitada por aspas no template (ex.: "to":"{{.To | jsonEscape}}"):
+// marshaleia via encoding/json e remove as aspas que o Marshal adiciona.
+func jsonEscape(s string) string {
+	escaped, _ := json.Marshal(s)
+	return string(escaped[1 : len(escaped)-1])
+}
+
+func NewSMSChannel(gatewayURL, requestTemplate string) (*SMSChannel, error) {
+	tmpl, err := template.New("sms-request").Funcs(template.FuncMap{"jsonEscape": jsonEscape}).Parse(requestTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("template da requisição de SMS inválido: %w", err)
+	}
+
+	return &SMSChannel{
+		gatewayURL: gatewayURL,
+		bodyTmpl:   tmpl,
+		httpClient: &http.Client{},
+	}, nil
+}
+
+func (c *SMSChannel) Name() string { return "sms" }
+
+func (c *SMSChannel) SendCode(phone, code string, expirationMinutes int) error {
+	body := fmt.Sprintf("Seu código de verificação é %s. Expira em %d minutos.", code, expirationMinutes)
+
+	var buf bytes.Buffer
+	if err := c.bodyTmpl.Execute(&buf, map[string]string{"To": phone, "Body": body}); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.gatewayURL, &buf)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var payload json.RawMessage
+		_ = json.NewDecoder(resp.Body).Decode(&payload)
+		return fmt.Errorf("gateway de SMS retornou %d: %s", resp.StatusCode, string(payload))
+	}
+
+	return nil
+}
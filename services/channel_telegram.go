@@ -0,0 +1,47 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// LinkNonceTTLMinutes é quanto tempo um nonce de vínculo (emitido por
+// /link/telegram) fica válido até o usuário mandá-lo para o bot.
+const LinkNonceTTLMinutes = 10
+
+// TelegramChannel envia pelo bot da API do Telegram. O `telegram_id` do
+// destinatário precisa já ter sido vinculado à conta via o fluxo de
+// /link/telegram + /link/telegram/confirm antes de qualquer código poder
+// ser enviado por este canal; quem garante isso é o AuthHandler.channelFor,
+// que resolve o telegram_id a partir do vínculo persistido em vez de
+// aceitar um valor solto no corpo da requisição.
+type TelegramChannel struct {
+	botToken   string
+	httpClient *http.Client
+}
+
+func NewTelegramChannel(botToken string) *TelegramChannel {
+	return &TelegramChannel{botToken: botToken, httpClient: &http.Client{}}
+}
+
+func (c *TelegramChannel) Name() string { return "telegram" }
+
+func (c *TelegramChannel) SendCode(telegramID, code string, expirationMinutes int) error {
+	text := fmt.Sprintf("Seu código de verificação é %s. Expira em %d minutos.", code, expirationMinutes)
+
+	apiURL := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", c.botToken)
+	resp, err := c.httpClient.PostForm(apiURL, url.Values{
+		"chat_id": {telegramID},
+		"text":    {text},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram API retornou status %d", resp.StatusCode)
+	}
+	return nil
+}
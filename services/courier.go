@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"email-api/config"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// Courier é a fila de envio assíncrona: Enqueue só grava a mensagem e
+// retorna; um worker em background drena a fila chamando o EmailService.
+type Courier struct {
+	store        CourierStore
+	emailService *EmailService
+	webhooks     *WebhookDispatcher
+
+	lastErrMu sync.RWMutex
+	lastErr   string
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// webhooks é opcional: passar nil desliga a emissão de eventos sem exigir
+// um WebhookDispatcher configurado (ex.: WEBHOOK_URLS vazio).
+func NewCourier(store CourierStore, emailService *EmailService, webhooks *WebhookDispatcher) *Courier {
+	return &Courier{
+		store:        store,
+		emailService: emailService,
+		webhooks:     webhooks,
+		stopCh:       make(chan struct{}),
+		doneCh:       make(chan struct{}),
+	}
+}
+
+func (c *Courier) emit(event WebhookEvent) {
+	if c.webhooks != nil {
+		c.webhooks.Emit(event)
+	}
+}
+
+// Enqueue persiste a mensagem e retorna imediatamente; o envio real
+// acontece no worker.
+func (c *Courier) Enqueue(m *Message) error {
+	return c.store.Enqueue(m)
+}
+
+// Start dispara o worker que faz polling da fila em background.
+func (c *Courier) Start() {
+	cfg := config.AppConfig
+	interval := time.Duration(cfg.CourierPollIntervalMS) * time.Millisecond
+
+	go func() {
+		defer close(c.doneCh)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-c.stopCh:
+				c.processBatch()
+				return
+			case <-ticker.C:
+				c.processBatch()
+			}
+		}
+	}()
+}
+
+// Stop sinaliza o worker para parar, drena o que estiver em voo e espera.
+func (c *Courier) Stop(ctx context.Context) error {
+	close(c.stopCh)
+
+	select {
+	case <-c.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Courier) processBatch() {
+	cfg := config.AppConfig
+
+	batch, err := c.store.DequeueBatch(cfg.CourierBatchSize)
+	if err != nil {
+		c.setLastErr(err.Error())
+		log.Printf("[courier] ❌ Erro ao buscar fila: %v\n", err)
+		return
+	}
+
+	for _, m := range batch {
+		c.send(m)
+	}
+}
+
+func (c *Courier) send(m *Message) {
+	cfg := config.AppConfig
+
+	err := c.emailService.sendEmail(m.To, m.Subject, m.TextBody, m.HTMLBody)
+	if err == nil {
+		if err := c.store.MarkSent(m.ID); err != nil {
+			log.Printf("[courier] ❌ Erro ao marcar enviado: %v\n", err)
+		}
+		c.emit(WebhookEvent{Type: EventMailSent, MessageID: m.ID, Email: m.To})
+		return
+	}
+
+	c.setLastErr(err.Error())
+	m.SendCount++
+
+	if m.SendCount >= cfg.CourierMaxSendCount {
+		log.Printf("[courier] ⚠️ Mensagem %d abandonada após %d tentativas: %v\n", m.ID, m.SendCount, err)
+		if err := c.store.MarkAbandoned(m.ID, err.Error()); err != nil {
+			log.Printf("[courier] ❌ Erro ao abandonar mensagem: %v\n", err)
+		}
+		c.emit(WebhookEvent{Type: EventMailAbandoned, MessageID: m.ID, Email: m.To, Error: err.Error()})
+		return
+	}
+
+	// A mensagem volta para "queued" com o send_count incrementado e
+	// next_attempt_at empurrado por backoff exponencial; DequeueBatch só a
+	// pega de novo depois desse horário, então tentativas sucessivas ficam
+	// cada vez mais espaçadas em vez de reaparecer a cada poll.
+	nextAttemptAt := time.Now().Add(courierBackoffDelay(m.SendCount))
+	if err := c.store.MarkFailed(m.ID, err.Error(), m.SendCount, nextAttemptAt); err != nil {
+		log.Printf("[courier] ❌ Erro ao marcar falha: %v\n", err)
+	}
+	c.emit(WebhookEvent{Type: EventMailFailed, MessageID: m.ID, Email: m.To, Error: err.Error()})
+}
+
+// courierBackoffDelay calcula o atraso até a próxima tentativa para uma
+// mensagem que já falhou attempt vezes, usando o mesmo
+// backoff.ExponentialBackOff empregado pelo WebhookDispatcher. Como
+// NextBackOff() é stateful, simulamos as `attempt` chamadas anteriores a
+// partir de uma instância nova para chegar no intervalo correspondente.
+func courierBackoffDelay(attempt int) time.Duration {
+	b := backoff.NewExponentialBackOff()
+	b.InitialInterval = time.Duration(config.AppConfig.CourierPollIntervalMS) * time.Millisecond
+	b.MaxInterval = 30 * time.Minute
+	b.MaxElapsedTime = 0 // nunca para de calcular; quem limita tentativas é CourierMaxSendCount
+	b.Reset()
+
+	delay := b.InitialInterval
+	for i := 0; i <= attempt; i++ {
+		delay = b.NextBackOff()
+	}
+	return delay
+}
+
+func (c *Courier) setLastErr(msg string) {
+	c.lastErrMu.Lock()
+	defer c.lastErrMu.Unlock()
+	c.lastErr = msg
+}
+
+// Status resume o estado da fila para o endpoint de observabilidade.
+func (c *Courier) Status() (depth int, lastErr string, err error) {
+	depth, err = c.store.QueueDepth()
+
+	c.lastErrMu.RLock()
+	lastErr = c.lastErr
+	c.lastErrMu.RUnlock()
+
+	return depth, lastErr, err
+}
@@ -0,0 +1,159 @@
+package services
+
+import (
+	"database/sql"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+type SQLiteCourierStore struct {
+	db *sql.DB
+}
+
+func NewSQLiteCourierStore(path string) (*SQLiteCourierStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS messages (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		to_addr TEXT NOT NULL,
+		subject TEXT NOT NULL,
+		text_body TEXT NOT NULL,
+		html_body TEXT NOT NULL,
+		template_id TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT 'queued',
+		send_count INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		next_attempt_at DATETIME NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	);`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	return &SQLiteCourierStore{db: db}, nil
+}
+
+func (s *SQLiteCourierStore) Enqueue(m *Message) error {
+	now := time.Now()
+	res, err := s.db.Exec(
+		`INSERT INTO messages (to_addr, subject, text_body, html_body, template_id, status, send_count, next_attempt_at, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, 'queued', 0, ?, ?, ?)`,
+		m.To, m.Subject, m.TextBody, m.HTMLBody, m.TemplateID, now, now, now,
+	)
+	if err != nil {
+		return err
+	}
+	m.ID, err = res.LastInsertId()
+	return err
+}
+
+func (s *SQLiteCourierStore) DequeueBatch(n int) ([]*Message, error) {
+	rows, err := s.db.Query(
+		`SELECT id, to_addr, subject, text_body, html_body, template_id, status, send_count, last_error, next_attempt_at, created_at, updated_at
+		 FROM messages WHERE status = 'queued' AND next_attempt_at <= ? ORDER BY created_at ASC LIMIT ?`, time.Now(), n,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []*Message
+	for rows.Next() {
+		m := &Message{}
+		if err := rows.Scan(&m.ID, &m.To, &m.Subject, &m.TextBody, &m.HTMLBody, &m.TemplateID,
+			&m.Status, &m.SendCount, &m.LastError, &m.NextAttemptAt, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		batch = append(batch, m)
+	}
+	rows.Close()
+
+	for _, m := range batch {
+		if _, err := s.db.Exec(`UPDATE messages SET status = 'processing', updated_at = ? WHERE id = ?`, time.Now(), m.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return batch, nil
+}
+
+func (s *SQLiteCourierStore) MarkSent(id int64) error {
+	_, err := s.db.Exec(`UPDATE messages SET status = 'sent', updated_at = ? WHERE id = ?`, time.Now(), id)
+	return err
+}
+
+// MarkFailed volta a mensagem para "queued" com o send_count incrementado
+// e next_attempt_at empurrado pelo backoff exponencial calculado pelo
+// chamador (ver Courier.send), para que DequeueBatch não a pegue de novo
+// antes da hora.
+func (s *SQLiteCourierStore) MarkFailed(id int64, errMsg string, nextSendCount int, nextAttemptAt time.Time) error {
+	_, err := s.db.Exec(
+		`UPDATE messages SET status = 'queued', last_error = ?, send_count = ?, next_attempt_at = ?, updated_at = ? WHERE id = ?`,
+		errMsg, nextSendCount, nextAttemptAt, time.Now(), id,
+	)
+	return err
+}
+
+func (s *SQLiteCourierStore) MarkAbandoned(id int64, errMsg string) error {
+	_, err := s.db.Exec(
+		`UPDATE messages SET status = 'abandoned', last_error = ?, updated_at = ? WHERE id = ?`,
+		errMsg, time.Now(), id,
+	)
+	return err
+}
+
+func (s *SQLiteCourierStore) Get(id int64) (*Message, error) {
+	m := &Message{}
+	err := s.db.QueryRow(
+		`SELECT id, to_addr, subject, text_body, html_body, template_id, status, send_count, last_error, next_attempt_at, created_at, updated_at
+		 FROM messages WHERE id = ?`, id,
+	).Scan(&m.ID, &m.To, &m.Subject, &m.TextBody, &m.HTMLBody, &m.TemplateID,
+		&m.Status, &m.SendCount, &m.LastError, &m.NextAttemptAt, &m.CreatedAt, &m.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return m, err
+}
+
+func (s *SQLiteCourierStore) List(email, status string) ([]*Message, error) {
+	query := `SELECT id, to_addr, subject, text_body, html_body, template_id, status, send_count, last_error, next_attempt_at, created_at, updated_at FROM messages WHERE 1=1`
+	var args []interface{}
+	if email != "" {
+		query += ` AND to_addr = ?`
+		args = append(args, email)
+	}
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	query += ` ORDER BY created_at DESC LIMIT 200`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*Message
+	for rows.Next() {
+		m := &Message{}
+		if err := rows.Scan(&m.ID, &m.To, &m.Subject, &m.TextBody, &m.HTMLBody, &m.TemplateID,
+			&m.Status, &m.SendCount, &m.LastError, &m.NextAttemptAt, &m.CreatedAt, &m.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (s *SQLiteCourierStore) QueueDepth() (int, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM messages WHERE status IN ('queued', 'processing')`).Scan(&n)
+	return n, err
+}
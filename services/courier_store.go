@@ -0,0 +1,36 @@
+package services
+
+import (
+	"email-api/config"
+	"fmt"
+	"time"
+)
+
+// CourierStore é o ponto de extensão para onde as mensagens ficam
+// persistidas. A implementação padrão usa SQLite; "redis" e "postgres"
+// seguem a mesma interface e podem ser plugadas por COURIER_STORE quando
+// implementadas.
+type CourierStore interface {
+	Enqueue(m *Message) error
+	// DequeueBatch só devolve mensagens "queued" cujo NextAttemptAt já
+	// passou, para que o backoff exponencial de MarkFailed seja respeitado.
+	DequeueBatch(n int) ([]*Message, error)
+	MarkSent(id int64) error
+	MarkFailed(id int64, errMsg string, nextSendCount int, nextAttemptAt time.Time) error
+	MarkAbandoned(id int64, errMsg string) error
+	Get(id int64) (*Message, error)
+	List(email, status string) ([]*Message, error)
+	QueueDepth() (int, error)
+}
+
+// NewCourierStore escolhe a implementação de acordo com COURIER_STORE.
+func NewCourierStore() (CourierStore, error) {
+	cfg := config.AppConfig
+
+	switch cfg.CourierStore {
+	case "", "sqlite":
+		return NewSQLiteCourierStore(cfg.CourierDBPath)
+	default:
+		return nil, fmt.Errorf("courier store %q não implementado", cfg.CourierStore)
+	}
+}
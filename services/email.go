@@ -0,0 +1,137 @@
+package services
+
+import (
+	"email-api/config"
+	"email-api/mail/templates"
+	"log"
+	"net/mail"
+
+	"gopkg.in/gomail.v2"
+)
+
+type EmailService struct {
+	dialer   *gomail.Dialer
+	from     *mail.Address
+	registry *templates.TemplateRegistry
+}
+
+func NewEmailService() *EmailService {
+	cfg := config.AppConfig
+
+	dialer := gomail.NewDialer(
+		cfg.SMTPHost,
+		cfg.SMTPPort,
+		cfg.SMTPUser,
+		cfg.SMTPPass,
+	)
+
+	// Desabilitar TLS se porta 25
+	if cfg.SMTPPort == 25 {
+		dialer.SSL = false
+	}
+
+	from, err := templates.ParseFromAddress(cfg.SMTPFrom)
+	if err != nil {
+		log.Fatalf("[email] ❌ SMTP_FROM inválido: %v\n", err)
+	}
+
+	registry, err := templates.NewTemplateRegistry()
+	if err != nil {
+		log.Fatalf("[email] ❌ Erro ao carregar templates: %v\n", err)
+	}
+
+	return &EmailService{
+		dialer:   dialer,
+		from:     from,
+		registry: registry,
+	}
+}
+
+func (s *EmailService) VerifyConnection() bool {
+	cfg := config.AppConfig
+
+	if cfg.EmailLogOnly {
+		log.Println("[email] Modo LOG_ONLY ativo")
+		return true
+	}
+
+	conn, err := s.dialer.Dial()
+	if err != nil {
+		log.Printf("[email] ❌ Erro na conexão: %v\n", err)
+		return false
+	}
+	defer conn.Close()
+
+	log.Println("[email] ✅ SMTP conectado")
+	return true
+}
+
+func (s *EmailService) sendEmail(to, subject, text, html string) error {
+	cfg := config.AppConfig
+
+	if cfg.EmailLogOnly {
+		log.Printf("[email][LOG_ONLY] to=%s subject=\"%s\"\n", to, subject)
+		return nil
+	}
+
+	m := gomail.NewMessage()
+	m.SetAddressHeader("From", s.from.Address, s.from.Name)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/plain", text)
+	m.AddAlternative("text/html", html)
+
+	if err := s.dialer.DialAndSend(m); err != nil {
+		log.Printf("[email] ❌ Erro ao enviar: %v\n", err)
+		return err
+	}
+
+	log.Printf("[email] ✅ Enviado para: %s\n", to)
+	return nil
+}
+
+// Send é a API pública do EmailService: renderiza `templateName`
+// (resolvendo o locale a partir de `data["Lang"]`) e devolve a Message
+// pronta para ser enfileirada no Courier.
+func (s *EmailService) Send(templateName, to string, data map[string]any) (*Message, error) {
+	lang, _ := data["Lang"].(string)
+
+	rendered, err := s.registry.Render(templateName, lang, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		To:         to,
+		Subject:    rendered.Subject,
+		TextBody:   rendered.Text,
+		HTMLBody:   rendered.HTML,
+		TemplateID: templateName,
+	}, nil
+}
+
+// BuildVerificationMessage e BuildPasswordResetMessage são atalhos sobre
+// Send para os dois fluxos já existentes em handlers/auth.go. verifyURL/
+// resetURL ficam vazios quando VERIFICATION_MODE="code" — os templates só
+// renderizam o link quando o valor correspondente não é vazio. lang
+// resolve o locale (ver handlers.resolveLang); vazio cai no template
+// padrão.
+func (s *EmailService) BuildVerificationMessage(to, code, verifyURL, lang string) (*Message, error) {
+	cfg := config.AppConfig
+	return s.Send("mail-verify", to, map[string]any{
+		"Code":              code,
+		"ExpirationMinutes": cfg.CodeExpirationMinutes,
+		"VerifyURL":         verifyURL,
+		"Lang":              lang,
+	})
+}
+
+func (s *EmailService) BuildPasswordResetMessage(to, code, resetURL, lang string) (*Message, error) {
+	cfg := config.AppConfig
+	return s.Send("mail-reset-password", to, map[string]any{
+		"Code":              code,
+		"ExpirationMinutes": cfg.CodeExpirationMinutes,
+		"ResetURL":          resetURL,
+		"Lang":              lang,
+	})
+}
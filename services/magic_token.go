@@ -0,0 +1,105 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"email-api/config"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Purpose indica se o token assinado serve para confirmar e-mail ou para
+// autorizar a troca de senha.
+type Purpose string
+
+const (
+	PurposeVerify Purpose = "verify"
+	PurposeReset  Purpose = "reset"
+)
+
+var ErrInvalidToken = errors.New("token inválido ou expirado")
+
+// MagicTokenPayload é o conteúdo assinado de um token de link mágico.
+type MagicTokenPayload struct {
+	Email   string  `json:"email"`
+	Purpose Purpose `json:"purpose"`
+	Exp     int64   `json:"exp"`
+	Nonce   string  `json:"nonce"`
+}
+
+// NewMagicToken gera o payload, assina com APP_SECRET e devolve o token
+// em base64url, junto do nonce (para ser guardado no CodeStorage).
+func NewMagicToken(email string, purpose Purpose, ttl time.Duration) (token, nonce string, err error) {
+	nonce, err = randomNonce()
+	if err != nil {
+		return "", "", err
+	}
+
+	payload := MagicTokenPayload{
+		Email:   email,
+		Purpose: purpose,
+		Exp:     time.Now().Add(ttl).Unix(),
+		Nonce:   nonce,
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", err
+	}
+
+	sig := sign(raw)
+	token = base64.RawURLEncoding.EncodeToString(raw) + "." + base64.RawURLEncoding.EncodeToString(sig)
+	return token, nonce, nil
+}
+
+// ParseMagicToken valida a assinatura e a expiração, mas não consome o
+// nonce — isso é responsabilidade de quem chama (precisa checar contra o
+// CodeStorage para garantir uso único).
+func ParseMagicToken(token string) (*MagicTokenPayload, error) {
+	rawPart, sigPart, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawPart)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(sigPart)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if !hmac.Equal(sig, sign(raw)) {
+		return nil, ErrInvalidToken
+	}
+
+	var payload MagicTokenPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if time.Now().Unix() > payload.Exp {
+		return nil, ErrInvalidToken
+	}
+
+	return &payload, nil
+}
+
+func sign(raw []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(config.AppConfig.AppSecret))
+	mac.Write(raw)
+	return mac.Sum(nil)
+}
+
+func randomNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
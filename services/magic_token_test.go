@@ -0,0 +1,51 @@
+package services
+
+import (
+	"email-api/config"
+	"testing"
+	"time"
+)
+
+func TestMagicTokenRoundTrip(t *testing.T) {
+	config.AppConfig = &config.Config{AppSecret: "segredo-de-teste"}
+
+	token, nonce, err := NewMagicToken("user@example.com", PurposeVerify, time.Minute)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	payload, err := ParseMagicToken(token)
+	if err != nil {
+		t.Fatalf("token deveria ser válido: %v", err)
+	}
+
+	if payload.Email != "user@example.com" || payload.Purpose != PurposeVerify || payload.Nonce != nonce {
+		t.Fatalf("payload inesperado: %+v", payload)
+	}
+}
+
+func TestMagicTokenRejectsExpired(t *testing.T) {
+	config.AppConfig = &config.Config{AppSecret: "segredo-de-teste"}
+
+	token, _, err := NewMagicToken("user@example.com", PurposeReset, -time.Minute)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if _, err := ParseMagicToken(token); err != ErrInvalidToken {
+		t.Fatalf("esperava ErrInvalidToken para token expirado, recebeu %v", err)
+	}
+}
+
+func TestMagicTokenRejectsTamperedSignature(t *testing.T) {
+	config.AppConfig = &config.Config{AppSecret: "segredo-de-teste"}
+
+	token, _, err := NewMagicToken("user@example.com", PurposeVerify, time.Minute)
+	if err != nil {
+		t.Fatalf("erro inesperado: %v", err)
+	}
+
+	if _, err := ParseMagicToken(token + "adulterado"); err != ErrInvalidToken {
+		t.Fatalf("esperava ErrInvalidToken para assinatura adulterada, recebeu %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import "time"
+
+// MessageStatus é o estado de uma mensagem dentro da fila do courier.
+type MessageStatus string
+
+const (
+	MessageQueued     MessageStatus = "queued"
+	MessageProcessing MessageStatus = "processing"
+	MessageSent       MessageStatus = "sent"
+	MessageAbandoned  MessageStatus = "abandoned"
+)
+
+// Message é uma linha da fila de envio: um e-mail ainda não entregue ou
+// já processado, junto do histórico de tentativas.
+type Message struct {
+	ID            int64
+	To            string
+	Subject       string
+	TextBody      string
+	HTMLBody      string
+	TemplateID    string
+	Status        MessageStatus
+	SendCount     int
+	LastError     string
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
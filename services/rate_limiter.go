@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// RateLimiter implementa um contador de janela fixa sobre Redis: cada
+// janela de tempo tem um contador que expira sozinho via TTL. Não é um
+// token-bucket: não há reposição gradual, o limite inteiro volta de uma
+// vez quando a chave expira.
+type RateLimiter struct {
+	client *redis.Client
+}
+
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{client: client}
+}
+
+// Allow incrementa o contador de `key` e diz se ainda está dentro do
+// limite `max` para a janela `window`; quando não está, retorna também o
+// tempo restante até a janela resetar (para o header Retry-After).
+func (r *RateLimiter) Allow(ctx context.Context, key string, max int, window time.Duration) (allowed bool, retryAfter time.Duration, err error) {
+	count, err := r.client.Incr(ctx, key).Result()
+	if err != nil {
+		return false, 0, err
+	}
+
+	if count == 1 {
+		if err := r.client.Expire(ctx, key, window).Err(); err != nil {
+			return false, 0, err
+		}
+	}
+
+	if count > int64(max) {
+		ttl, err := r.client.TTL(ctx, key).Result()
+		if err != nil {
+			return false, 0, err
+		}
+		return false, ttl, nil
+	}
+
+	return true, 0, nil
+}
+
+func EmailRateLimitKey(email string) string { return fmt.Sprintf("ratelimit:email:%s", email) }
+func IPRateLimitKey(ip string) string       { return fmt.Sprintf("ratelimit:ip:%s", ip) }
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"email-api/config"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type CodeData struct {
+	Code      string
+	ExpiresAt time.Time
+}
+
+// codeKey chaveia os códigos por canal + identificador (e-mail, telefone
+// ou telegram_id), para que o mesmo identificador não colida entre
+// canais diferentes.
+type codeKey struct {
+	Channel    string
+	Identifier string
+}
+
+type CodeStorage struct {
+	verificationCodes map[codeKey]CodeData
+	resetCodes        map[codeKey]CodeData
+	linkNonces        map[codeKey]CodeData
+	telegramBindings  map[string]string
+	mu                sync.RWMutex
+}
+
+var storage *CodeStorage
+
+func NewCodeStorage() *CodeStorage {
+	if storage == nil {
+		storage = &CodeStorage{
+			verificationCodes: make(map[codeKey]CodeData),
+			resetCodes:        make(map[codeKey]CodeData),
+			linkNonces:        make(map[codeKey]CodeData),
+			telegramBindings:  make(map[string]string),
+		}
+
+		// Limpar códigos expirados a cada 1 minuto
+		go storage.cleanExpiredCodes()
+	}
+	return storage
+}
+
+func (s *CodeStorage) GenerateCode() string {
+	rand.Seed(time.Now().UnixNano())
+	code := rand.Intn(900000) + 100000
+	return fmt.Sprintf("%06d", code)
+}
+
+func (s *CodeStorage) SaveVerificationCode(channel, identifier, code string) {
+	cfg := config.AppConfig
+	expiresAt := time.Now().Add(time.Duration(cfg.CodeExpirationMinutes) * time.Minute)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verificationCodes[codeKey{channel, identifier}] = CodeData{
+		Code:      code,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *CodeStorage) GetVerificationCode(channel, identifier string) *CodeData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.verificationCodes[codeKey{channel, identifier}]
+	if !exists {
+		return nil
+	}
+
+	if time.Now().After(data.ExpiresAt) {
+		return nil
+	}
+
+	return &data
+}
+
+func (s *CodeStorage) DeleteVerificationCode(channel, identifier string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.verificationCodes, codeKey{channel, identifier})
+}
+
+func (s *CodeStorage) SaveResetCode(channel, identifier, code string) {
+	cfg := config.AppConfig
+	expiresAt := time.Now().Add(time.Duration(cfg.CodeExpirationMinutes) * time.Minute)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resetCodes[codeKey{channel, identifier}] = CodeData{
+		Code:      code,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *CodeStorage) GetResetCode(channel, identifier string) *CodeData {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, exists := s.resetCodes[codeKey{channel, identifier}]
+	if !exists {
+		return nil
+	}
+
+	if time.Now().After(data.ExpiresAt) {
+		return nil
+	}
+
+	return &data
+}
+
+func (s *CodeStorage) DeleteResetCode(channel, identifier string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.resetCodes, codeKey{channel, identifier})
+}
+
+// SaveLinkNonce e ConsumeLinkNonce sustentam o fluxo de /link/telegram:
+// o nonce emitido é de uso único e expira sozinho como os demais códigos.
+func (s *CodeStorage) SaveLinkNonce(channel, identifier, nonce string) {
+	expiresAt := time.Now().Add(LinkNonceTTLMinutes * time.Minute)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.linkNonces[codeKey{channel, identifier}] = CodeData{
+		Code:      nonce,
+		ExpiresAt: expiresAt,
+	}
+}
+
+func (s *CodeStorage) ConsumeLinkNonce(channel, identifier, nonce string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := codeKey{channel, identifier}
+	data, exists := s.linkNonces[key]
+	if !exists || time.Now().After(data.ExpiresAt) || data.Code != nonce {
+		return false
+	}
+
+	delete(s.linkNonces, key)
+	return true
+}
+
+// ConfirmTelegramLink troca o nonce emitido por SaveLinkNonce("telegram", ...)
+// pelo telegram_id que o bot confirma ter recebido, e persiste o vínculo
+// conta -> chat. channelFor só resolve um telegram_id para uma conta depois
+// que esse vínculo existe — sem ele o canal "telegram" fica sem
+// identificador e nenhum código é enviado.
+func (s *CodeStorage) ConfirmTelegramLink(email, nonce, telegramID string) bool {
+	if !s.ConsumeLinkNonce("telegram", email, nonce) {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.telegramBindings[email] = telegramID
+	return true
+}
+
+// GetTelegramBinding devolve o telegram_id vinculado à conta, se houver.
+func (s *CodeStorage) GetTelegramBinding(email string) (string, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	telegramID, ok := s.telegramBindings[email]
+	return telegramID, ok
+}
+
+// magicVerifyChannel e magicResetChannel prefixam os nonces de link mágico para que não colidam
+// com os nonces de /link/telegram, que também moram em linkNonces.
+const (
+	magicVerifyChannel = "magic-verify"
+	magicResetChannel  = "magic-reset"
+)
+
+// SaveVerificationNonce, ConsumeVerificationNonce e os equivalentes de
+// reset seguem exatamente o mesmo padrão de uso único de SaveLinkNonce/
+// ConsumeLinkNonce, só que chaveados por e-mail em vez de canal.
+func (s *CodeStorage) SaveVerificationNonce(email, nonce string) {
+	s.SaveLinkNonce(magicVerifyChannel, email, nonce)
+}
+
+func (s *CodeStorage) ConsumeVerificationNonce(email, nonce string) bool {
+	return s.ConsumeLinkNonce(magicVerifyChannel, email, nonce)
+}
+
+func (s *CodeStorage) SaveResetNonce(email, nonce string) {
+	s.SaveLinkNonce(magicResetChannel, email, nonce)
+}
+
+func (s *CodeStorage) ConsumeResetNonce(email, nonce string) bool {
+	return s.ConsumeLinkNonce(magicResetChannel, email, nonce)
+}
+
+func (s *CodeStorage) cleanExpiredCodes() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		now := time.Now()
+
+		s.mu.Lock()
+
+		for key, data := range s.verificationCodes {
+			if now.After(data.ExpiresAt) {
+				delete(s.verificationCodes, key)
+			}
+		}
+
+		for key, data := range s.resetCodes {
+			if now.After(data.ExpiresAt) {
+				delete(s.resetCodes, key)
+			}
+		}
+
+		for key, data := range s.linkNonces {
+			if now.After(data.ExpiresAt) {
+				delete(s.linkNonces, key)
+			}
+		}
+
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"email-api/config"
+	"testing"
+)
+
+func TestGenerateCodeFormat(t *testing.T) {
+	s := &CodeStorage{}
+	code := s.GenerateCode()
+
+	if len(code) != 6 {
+		t.Fatalf("esperava código com 6 dígitos, recebeu %q", code)
+	}
+}
+
+func TestVerificationCodeRoundTrip(t *testing.T) {
+	config.AppConfig = &config.Config{CodeExpirationMinutes: 15}
+
+	s := &CodeStorage{
+		verificationCodes: make(map[codeKey]CodeData),
+		resetCodes:        make(map[codeKey]CodeData),
+		linkNonces:        make(map[codeKey]CodeData),
+	}
+
+	s.SaveVerificationCode("email", "user@example.com", "123456")
+
+	got := s.GetVerificationCode("email", "user@example.com")
+	if got == nil || got.Code != "123456" {
+		t.Fatalf("esperava código salvo, recebeu %+v", got)
+	}
+
+	s.DeleteVerificationCode("email", "user@example.com")
+	if s.GetVerificationCode("email", "user@example.com") != nil {
+		t.Fatal("código deveria ter sido removido")
+	}
+}
+
+func TestVerificationCodeDoesNotCrossChannels(t *testing.T) {
+	config.AppConfig = &config.Config{CodeExpirationMinutes: 15}
+
+	s := &CodeStorage{
+		verificationCodes: make(map[codeKey]CodeData),
+		resetCodes:        make(map[codeKey]CodeData),
+		linkNonces:        make(map[codeKey]CodeData),
+	}
+
+	s.SaveVerificationCode("sms", "+5511999999999", "111111")
+
+	if s.GetVerificationCode("email", "+5511999999999") != nil {
+		t.Fatal("código de um canal não deveria aparecer em outro canal com o mesmo identificador")
+	}
+}
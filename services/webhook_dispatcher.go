@@ -0,0 +1,112 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"email-api/config"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// WebhookDispatcher entrega eventos para as URLs configuradas de forma
+// assíncrona, com retentativas e dead-letter persistido após esgotar as
+// tentativas. Ao contrário da fila do Courier, a fila de eventos é um canal
+// em memória: eventos pendentes se perdem num restart e são descartados
+// quando o canal está cheio; só os dead-letters são persistidos.
+type WebhookDispatcher struct {
+	urls       []string
+	secret     string
+	httpClient *http.Client
+	queue      chan WebhookEvent
+	deadLetter *WebhookDeadLetterStore
+}
+
+func NewWebhookDispatcher(deadLetter *WebhookDeadLetterStore) *WebhookDispatcher {
+	cfg := config.AppConfig
+	return &WebhookDispatcher{
+		urls:       cfg.WebhookURLs,
+		secret:     cfg.WebhookSecret,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		queue:      make(chan WebhookEvent, 256),
+		deadLetter: deadLetter,
+	}
+}
+
+// Emit enfileira o evento para entrega; nunca bloqueia o chamador.
+func (d *WebhookDispatcher) Emit(event WebhookEvent) {
+	if len(d.urls) == 0 {
+		return
+	}
+	event.CreatedAt = time.Now()
+
+	select {
+	case d.queue <- event:
+	default:
+		log.Printf("[webhook] ⚠️ fila cheia, evento %s descartado\n", event.Type)
+	}
+}
+
+// Start consome a fila em background e entrega cada evento a cada URL
+// configurada, com backoff exponencial por tentativa.
+func (d *WebhookDispatcher) Start() {
+	go func() {
+		for event := range d.queue {
+			for _, url := range d.urls {
+				d.deliverWithRetry(url, event)
+			}
+		}
+	}()
+}
+
+func (d *WebhookDispatcher) deliverWithRetry(url string, event WebhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("[webhook] ❌ erro ao serializar evento: %v\n", err)
+		return
+	}
+
+	operation := func() error { return d.deliver(url, body) }
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 2 * time.Minute
+
+	if err := backoff.Retry(operation, b); err != nil {
+		log.Printf("[webhook] ⚠️ evento %s para %s indo para dead-letter: %v\n", event.Type, url, err)
+		if dlErr := d.deadLetter.Save(url, event, err.Error()); dlErr != nil {
+			log.Printf("[webhook] ❌ erro ao persistir dead-letter: %v\n", dlErr)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) deliver(url string, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature", "sha256="+d.sign(body))
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("status HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(d.secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
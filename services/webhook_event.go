@@ -0,0 +1,38 @@
+package services
+
+import "time"
+
+// EventType é o conjunto fechado de eventos que disparam um webhook.
+type EventType string
+
+const (
+	EventCodeIssued    EventType = "code.issued"
+	EventCodeVerified  EventType = "code.verified"
+	EventCodeExpired   EventType = "code.expired"
+	EventMailSent      EventType = "mail.sent"
+	EventMailFailed    EventType = "mail.failed"
+	EventMailAbandoned EventType = "mail.abandoned"
+)
+
+// WebhookEvent é o corpo (antes de assinar) enviado para cada URL
+// configurada em WEBHOOK_URLS.
+type WebhookEvent struct {
+	Type      EventType `json:"type"`
+	MessageID int64     `json:"messageId,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	Channel   string    `json:"channel,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// DeadLetter é um evento que esgotou as retentativas de entrega,
+// persistido para sobreviver a um restart do processo e poder ser
+// inspecionado pela API administrativa.
+type DeadLetter struct {
+	ID        int64     `json:"id"`
+	EventType EventType `json:"eventType"`
+	URL       string    `json:"url"`
+	Payload   string    `json:"payload"`
+	LastError string    `json:"lastError"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// WebhookDeadLetterStore persiste, no mesmo arquivo SQLite usado pelo
+// CourierStore, os eventos de webhook que esgotaram as retentativas de
+// entrega. Sem isso um dead-letter em memória some no primeiro restart
+// do processo — o oposto do que "dead-lettering" deveria garantir.
+type WebhookDeadLetterStore struct {
+	db *sql.DB
+}
+
+func NewWebhookDeadLetterStore(path string) (*WebhookDeadLetterStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		event_type TEXT NOT NULL,
+		url TEXT NOT NULL,
+		payload TEXT NOT NULL,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at DATETIME NOT NULL
+	);`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	return &WebhookDeadLetterStore{db: db}, nil
+}
+
+// Save grava o evento que não pôde ser entregue a `url` após esgotar as
+// retentativas.
+func (s *WebhookDeadLetterStore) Save(url string, event WebhookEvent, lastError string) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO webhook_dead_letters (event_type, url, payload, last_error, created_at) VALUES (?, ?, ?, ?, ?)`,
+		string(event.Type), url, string(payload), lastError, time.Now(),
+	)
+	return err
+}
+
+// List devolve os dead letters mais recentes, para a rota administrativa.
+func (s *WebhookDeadLetterStore) List() ([]*DeadLetter, error) {
+	rows, err := s.db.Query(
+		`SELECT id, event_type, url, payload, last_error, created_at
+		 FROM webhook_dead_letters ORDER BY created_at DESC LIMIT 200`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*DeadLetter
+	for rows.Next() {
+		d := &DeadLetter{}
+		var eventType string
+		if err := rows.Scan(&d.ID, &eventType, &d.URL, &d.Payload, &d.LastError, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.EventType = EventType(eventType)
+		out = append(out, d)
+	}
+	return out, nil
+}